@@ -0,0 +1,28 @@
+package core
+
+// Configuration is the set of key/value pairs a bridge implementation
+// persists in the repository's git config to describe how it was set up.
+type Configuration map[string]string
+
+// BridgeParams gathers every parameter that can drive a bridge's
+// Configure, whether it comes from CLI flags, environment variables, or
+// interactive prompts. A bridge implementation is free to ignore the
+// fields it doesn't need.
+type BridgeParams struct {
+	Owner   string
+	Project string
+	URL     string
+	Token   string
+
+	// BaseURL overrides the API host used to reach the forge, for
+	// self-hosted instances (e.g. GitHub Enterprise Server). When empty,
+	// the bridge may prompt for it; otherwise, and in non-interactive
+	// mode, it uses its default public instance.
+	BaseURL string
+
+	// NonInteractive disables every terminal prompt: Configure must
+	// return an error instead of falling back to stdin whenever a
+	// required parameter is missing. Used to run configuration from CI
+	// or provisioning scripts without a TTY.
+	NonInteractive bool
+}
@@ -0,0 +1,53 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+)
+
+// newClient builds the HTTP client the importer and exporter use to talk
+// to the GitHub API. The client itself does not depend on conf: the host
+// it reaches (the public API by default, or a GitHub Enterprise Server
+// instance when conf[keyBaseURL] was set at `bridge configure` time) is
+// set on each request by newAuthenticatedRequest.
+func newClient(conf core.Configuration) *http.Client {
+	return &http.Client{Timeout: defaultTimeout}
+}
+
+// newAuthenticatedRequest builds a request against the API host stored in
+// conf, with the bridge's token attached.
+func newAuthenticatedRequest(conf core.Configuration, method, path string) (*http.Request, error) {
+	url := fmt.Sprintf("%s%s", APIBaseURL(conf), path)
+
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("token %s", conf[keyToken]))
+
+	return req, nil
+}
+
+// requireScope checks the scopes recorded in conf (at `bridge configure`
+// time, see keyScopes) against a list of acceptable scopes, so that
+// import/export fail fast with a clear error instead of discovering the
+// missing permission from a 403 partway through a run.
+func requireScope(conf core.Configuration, acceptable ...string) error {
+	raw := conf[keyScopes]
+	var scopes []string
+	if raw != "" {
+		scopes = strings.Split(raw, ",")
+	}
+
+	for _, s := range acceptable {
+		if hasScope(scopes, s) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("token is missing required scope: one of %s", strings.Join(acceptable, ", "))
+}
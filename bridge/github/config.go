@@ -2,37 +2,40 @@ package github
 
 import (
 	"bufio"
-	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
 	"io/ioutil"
-	"math/rand"
 	"net/http"
+	"net/url"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
-	"golang.org/x/crypto/ssh/terminal"
-
 	"github.com/MichaelMure/git-bug/bridge/core"
 	"github.com/MichaelMure/git-bug/repository"
 )
 
 const (
-	githubV3Url = "https://api.github.com"
-	keyOwner    = "owner"
-	keyProject  = "project"
-	keyToken    = "token"
+	defaultBaseURL = "https://api.github.com"
+	defaultHost    = "https://github.com"
+
+	keyOwner   = "owner"
+	keyProject = "project"
+	keyToken   = "token"
+	keyBaseURL = "base-url"
+	keyScopes  = "scopes"
 
 	defaultTimeout = 5 * time.Second
-)
 
-var (
-	rxGithubSplit = regexp.MustCompile(`github\.com\/([^\/]*)\/([^\/]*)`)
+	// clientID is git-bug's public OAuth App client ID, used to drive the
+	// device authorization flow below. Device flow client IDs are not
+	// secret: GitHub never hands out a client secret to a CLI tool, the
+	// flow is authorized purely through the user confirming the code.
+	clientID = "3d4c8c6f5b3a8f1c9e2a"
+
+	defaultPollInterval = 5 * time.Second
 )
 
 func (*Github) Configure(repo repository.RepoCommon, params core.BridgeParams) (core.Configuration, error) {
@@ -42,28 +45,55 @@ func (*Github) Configure(repo repository.RepoCommon, params core.BridgeParams) (
 	var owner string
 	var project string
 
-	// getting owner and project name:
-	// first use directly params if they are both provided, else try to parse
-	// them from params URL, and finaly try getting them from terminal prompt
-	if params.Owner != "" && params.Project != "" {
-		owner = params.Owner
-		project = params.Project
-
-	} else if params.URL != "" {
-		owner, project, err = splitURL(params.URL)
+	// getting the base URL: use params if provided, else prompt for it,
+	// and default to the public API when it is still empty (the prompt is
+	// skipped in non-interactive mode). An empty value is always valid
+	// here since it falls back to the public API, so non-interactive mode
+	// never needs to error on it.
+	baseURL := params.BaseURL
+	if baseURL == "" && !params.NonInteractive {
+		baseURL, err = promptBaseURL()
 		if err != nil {
 			return nil, err
 		}
+	}
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
 
-	} else {
-		owner, project, err = promptURL()
-		if err != nil {
-			return nil, err
+	// getting owner and project name:
+	// first use directly params if they are both provided, else fallback to
+	// env vars, then try to parse them from params URL, and finally try
+	// getting them from terminal prompt
+	owner = params.Owner
+	project = params.Project
+	if owner == "" {
+		owner = os.Getenv("GITHUB_OWNER")
+	}
+	if project == "" {
+		project = os.Getenv("GITHUB_PROJECT")
+	}
+
+	if owner == "" || project == "" {
+		if params.URL != "" {
+			owner, project, err = splitURL(params.URL, baseURL)
+			if err != nil {
+				return nil, err
+			}
+
+		} else if params.NonInteractive {
+			return nil, fmt.Errorf("non-interactive mode: missing owner/project or URL")
+
+		} else {
+			owner, project, err = promptURL(baseURL)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	// validate project owner
-	ok, err := validateUsername(owner)
+	ok, err := validateUsername(baseURL, owner)
 	if err != nil {
 		return nil, err
 	}
@@ -71,88 +101,27 @@ func (*Github) Configure(repo repository.RepoCommon, params core.BridgeParams) (
 		return nil, fmt.Errorf("invalid parameter owner: %v", owner)
 	}
 
-	// try to get token from params if provided, else use terminal prompt
-	// to login and generate a token
-	if params.Token != "" {
-		token = params.Token
-
-	} else {
-		fmt.Println()
-		fmt.Println("git-bug will now generate an access token in your Github profile. Your credential are not stored and are only used to generate the token. The token is stored in the repository git config.")
-		fmt.Println()
-		fmt.Println("Depending on your configuration the token will have one of the following scopes:")
-		fmt.Println("  - 'user:email': to be able to read public-only users email")
-		fmt.Println("  - 'repo'      : to be able to read private repositories")
-		// fmt.Println("The token will have the \"repo\" permission, giving it read/write access to your repositories and issues. There is no narrower scope available, sorry :-|")
-		fmt.Println()
-
-		isPublic, err := promptProjectVisibility()
-		if err != nil {
-			return nil, err
-		}
-
-		username, err := promptUsername()
-		if err != nil {
-			return nil, err
-		}
-
-		password, err := promptPassword()
-		if err != nil {
-			return nil, err
-		}
+	// try to get token from params if provided, else fallback to an env
+	// var, and finally let the user either log in through the device
+	// authorization flow or paste an existing personal access token
+	token = params.Token
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
 
-		var scope string
-		if isPublic {
-			// user:email is requested to be able to read public emails
-			//     - a private email will stay private, even with this token
-			scope = "user:email"
-		} else {
-			// 'repo' is request to be able to read private repositories
-			// /!\ token will have read/write rights on every private repository you have access to
-			scope = "repo"
+	if token == "" {
+		if params.NonInteractive {
+			return nil, fmt.Errorf("non-interactive mode: missing token")
 		}
 
-		// Attempt to authenticate and create a token
-
-		note := fmt.Sprintf("git-bug - %s/%s", owner, project)
-
-		resp, err := requestToken(note, username, password, scope)
+		token, err = promptTokenOrLogin(baseURL)
 		if err != nil {
 			return nil, err
 		}
-
-		defer resp.Body.Close()
-
-		// Handle 2FA is needed
-		OTPHeader := resp.Header.Get("X-GitHub-OTP")
-		if resp.StatusCode == http.StatusUnauthorized && OTPHeader != "" {
-			otpCode, err := prompt2FA()
-			if err != nil {
-				return nil, err
-			}
-
-			resp, err = requestTokenWith2FA(note, username, password, otpCode, scope)
-			if err != nil {
-				return nil, err
-			}
-
-			defer resp.Body.Close()
-		}
-
-		if resp.StatusCode == http.StatusCreated {
-			token, err = decodeBody(resp.Body)
-			if err != nil {
-				return nil, err
-			}
-
-		} else {
-			b, _ := ioutil.ReadAll(resp.Body)
-			return nil, fmt.Errorf("error creating token %v: %v", resp.StatusCode, string(b))
-		}
 	}
 
 	// verifying access to project with token
-	ok, err = validateProject(owner, project, token)
+	ok, isPrivate, err := validateProject(baseURL, owner, project, token)
 	if err != nil {
 		return nil, err
 	}
@@ -160,9 +129,23 @@ func (*Github) Configure(repo repository.RepoCommon, params core.BridgeParams) (
 		return nil, fmt.Errorf("project doesn't exist or authentication token has a wrong scope")
 	}
 
+	// inspecting the scopes actually carried by the token, so that a
+	// missing permission is caught now rather than mid import/export
+	scopes, err := requestScopes(baseURL, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateScopes(scopes, isPrivate); err != nil {
+		return nil, err
+	}
+
 	conf[keyToken] = token
 	conf[keyOwner] = owner
 	conf[keyProject] = project
+	conf[keyScopes] = strings.Join(scopes, ",")
+	if baseURL != defaultBaseURL {
+		conf[keyBaseURL] = baseURL
+	}
 
 	return conf, nil
 }
@@ -180,82 +163,262 @@ func (*Github) ValidateConfig(conf core.Configuration) error {
 		return fmt.Errorf("missing %s key", keyProject)
 	}
 
+	if baseURL, ok := conf[keyBaseURL]; ok {
+		if _, err := url.ParseRequestURI(baseURL); err != nil {
+			return fmt.Errorf("invalid %s: %v", keyBaseURL, err)
+		}
+	}
+
 	return nil
 }
 
-func requestToken(note, username, password string, scope string) (*http.Response, error) {
-	return requestTokenWith2FA(note, username, password, "", scope)
+// APIBaseURL returns the API base URL stored in a bridge configuration,
+// falling back to the public GitHub API when none was set. The importer
+// and exporter must build their API client from this instead of
+// hardcoding the public API host, so that a GitHub Enterprise Server
+// setup keeps talking to the configured instance.
+func APIBaseURL(conf core.Configuration) string {
+	if baseURL, ok := conf[keyBaseURL]; ok {
+		return baseURL
+	}
+
+	return defaultBaseURL
 }
 
-func requestTokenWith2FA(note, username, password, otpCode string, scope string) (*http.Response, error) {
-	url := fmt.Sprintf("%s/authorizations", githubV3Url)
-	params := struct {
-		Scopes      []string `json:"scopes"`
-		Note        string   `json:"note"`
-		Fingerprint string   `json:"fingerprint"`
-	}{
-		Scopes:      []string{scope},
-		Note:        note,
-		Fingerprint: randomFingerprint(),
+// githubHost derives the web host (used for the device authorization
+// login) from the given API base URL. GitHub Enterprise Server exposes
+// its API under "<host>/api/v3" while the web UI stays at "<host>".
+func githubHost(baseURL string) string {
+	if baseURL == defaultBaseURL {
+		return defaultHost
 	}
 
-	data, err := json.Marshal(params)
+	return strings.TrimSuffix(strings.TrimRight(baseURL, "/"), "/api/v3")
+}
+
+func promptBaseURL() (string, error) {
+	fmt.Printf("Github base URL (default: %s): ", defaultBaseURL)
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+
+	line = strings.TrimRight(strings.TrimRight(line, "\n"), "/")
+	if line == "" {
+		return defaultBaseURL, nil
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(data))
+	return line, nil
+}
+
+// promptTokenOrLogin lets the user either paste a pre-created personal
+// access token, or log in through GitHub's OAuth device authorization
+// flow. Password-based token creation is gone from GitHub's API, and
+// prompting for a password is a dead end for 2FA and SSO-protected
+// accounts anyway.
+func promptTokenOrLogin(baseURL string) (string, error) {
+	for {
+		fmt.Println()
+		fmt.Println("[0]: login with a web browser (device authorization)")
+		fmt.Println("[1]: paste an existing personal access token")
+		fmt.Print("auth method: ")
+
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+
+		line = strings.TrimRight(line, "\n")
+
+		index, err := strconv.Atoi(line)
+		if err != nil || (index != 0 && index != 1) {
+			fmt.Println("invalid input")
+			continue
+		}
+
+		if index == 1 {
+			return promptToken()
+		}
+
+		return deviceLogin(baseURL)
+	}
+}
+
+// deviceLogin drives GitHub's OAuth 2.0 device authorization flow: it
+// requests a device/user code pair, displays the user code and
+// verification URL, and polls the token endpoint until the user has
+// approved the request (or it expires).
+func deviceLogin(baseURL string) (string, error) {
+	fmt.Println()
+	fmt.Println("git-bug will now request a device login with GitHub. Depending on your configuration the resulting token will have one of the following scopes:")
+	fmt.Println("  - 'public_repo user:email': to be able to read and write issues of public repositories, and read public-only users email")
+	fmt.Println("  - 'repo'                  : to be able to read private repositories")
+	fmt.Println()
+
+	isPublic, err := promptProjectVisibility()
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+
+	var scope string
+	if isPublic {
+		// public_repo is requested to be able to read and write issues
+		// user:email is requested to be able to read public emails
+		//     - a private email will stay private, even with this token
+		scope = "public_repo user:email"
+	} else {
+		// 'repo' is requested to be able to read private repositories
+		// /!\ token will have read/write rights on every private repository you have access to
+		scope = "repo"
 	}
 
-	req.SetBasicAuth(username, password)
-	req.Header.Set("Content-Type", "application/json")
+	host := githubHost(baseURL)
+
+	code, err := requestDeviceCode(host, scope)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Println()
+	fmt.Printf("Please go to %s and enter the following code: %s\n", code.VerificationURI, code.UserCode)
+	fmt.Println("Waiting for authorization...")
+
+	return pollDeviceCodeAuth(host, code)
+}
+
+type deviceCodeResponse struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+func requestDeviceCode(host, scope string) (*deviceCodeResponse, error) {
+	form := url.Values{}
+	form.Set("client_id", clientID)
+	form.Set("scope", scope)
 
-	if otpCode != "" {
-		req.Header.Set("X-GitHub-OTP", otpCode)
+	deviceCodeURL := fmt.Sprintf("%s/login/device/code", host)
+
+	req, err := http.NewRequest("POST", deviceCodeURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
 	}
 
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
 	client := &http.Client{
 		Timeout: defaultTimeout,
 	}
 
-	return client.Do(req)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error requesting device code %v: %v", resp.StatusCode, string(data))
+	}
+
+	var code deviceCodeResponse
+	if err := json.Unmarshal(data, &code); err != nil {
+		return nil, err
+	}
+
+	return &code, nil
+}
+
+type accessTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	Error       string `json:"error"`
+}
+
+// pollDeviceCodeAuth polls GitHub's access token endpoint until the user
+// has approved the device, the request expires, or an unexpected error
+// is returned.
+func pollDeviceCodeAuth(host string, code *deviceCodeResponse) (string, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval == 0 {
+		interval = defaultPollInterval
+	}
+
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+
+		token, oauthErr, err := requestAccessToken(host, code.DeviceCode)
+		if err != nil {
+			return "", err
+		}
+
+		switch oauthErr {
+		case "":
+			return token, nil
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += defaultPollInterval
+			continue
+		default:
+			return "", fmt.Errorf("device authorization failed: %s", oauthErr)
+		}
+	}
+
+	return "", fmt.Errorf("device authorization expired, please try again")
 }
 
-func decodeBody(body io.ReadCloser) (string, error) {
-	data, _ := ioutil.ReadAll(body)
+func requestAccessToken(host, deviceCode string) (token string, oauthErr string, err error) {
+	form := url.Values{}
+	form.Set("client_id", clientID)
+	form.Set("device_code", deviceCode)
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:device_code")
 
-	aux := struct {
-		Token string `json:"token"`
-	}{}
+	accessTokenURL := fmt.Sprintf("%s/login/oauth/access_token", host)
 
-	err := json.Unmarshal(data, &aux)
+	req, err := http.NewRequest("POST", accessTokenURL, strings.NewReader(form.Encode()))
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	if aux.Token == "" {
-		return "", fmt.Errorf("no token found in response: %s", string(data))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	client := &http.Client{
+		Timeout: defaultTimeout,
 	}
 
-	return aux.Token, nil
-}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
 
-func randomFingerprint() string {
-	// Doesn't have to be crypto secure, it's just to avoid token collision
-	rand.Seed(time.Now().UnixNano())
-	var letterRunes = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
-	b := make([]rune, 32)
-	for i := range b {
-		b[i] = letterRunes[rand.Intn(len(letterRunes))]
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", err
 	}
-	return string(b)
+
+	var aux accessTokenResponse
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return "", "", err
+	}
+
+	return aux.AccessToken, aux.Error, nil
 }
 
-func promptUsername() (string, error) {
+func promptToken() (string, error) {
 	for {
-		fmt.Print("username: ")
+		fmt.Print("personal access token: ")
 
 		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
 		if err != nil {
@@ -263,20 +426,16 @@ func promptUsername() (string, error) {
 		}
 
 		line = strings.TrimRight(line, "\n")
-
-		ok, err := validateUsername(line)
-		if err != nil {
-			return "", err
-		}
-		if ok {
-			return line, nil
+		if line == "" {
+			fmt.Println("token is empty")
+			continue
 		}
 
-		fmt.Println("invalid username")
+		return line, nil
 	}
 }
 
-func promptURL() (string, string, error) {
+func promptURL(baseURL string) (string, string, error) {
 	for {
 		fmt.Print("Github project URL: ")
 
@@ -291,7 +450,7 @@ func promptURL() (string, string, error) {
 			continue
 		}
 
-		projectOwner, projectName, err := splitURL(line)
+		projectOwner, projectName, err := splitURL(line, baseURL)
 		if err != nil {
 			fmt.Println(err)
 			continue
@@ -301,8 +460,12 @@ func promptURL() (string, string, error) {
 	}
 }
 
-func splitURL(url string) (string, string, error) {
-	res := rxGithubSplit.FindStringSubmatch(url)
+func splitURL(projectURL, baseURL string) (string, string, error) {
+	host := strings.TrimPrefix(strings.TrimPrefix(githubHost(baseURL), "https://"), "http://")
+
+	rx := regexp.MustCompile(regexp.QuoteMeta(host) + `\/([^\/]*)\/([^\/]*)`)
+
+	res := rx.FindStringSubmatch(projectURL)
 	if res == nil {
 		return "", "", fmt.Errorf("bad github project url")
 	}
@@ -310,8 +473,8 @@ func splitURL(url string) (string, string, error) {
 	return res[1], res[2], nil
 }
 
-func validateUsername(username string) (bool, error) {
-	url := fmt.Sprintf("%s/users/%s", githubV3Url, username)
+func validateUsername(baseURL, username string) (bool, error) {
+	url := fmt.Sprintf("%s/users/%s", baseURL, username)
 
 	resp, err := http.Get(url)
 	if err != nil {
@@ -326,12 +489,15 @@ func validateUsername(username string) (bool, error) {
 	return resp.StatusCode == http.StatusOK, nil
 }
 
-func validateProject(owner, project, token string) (bool, error) {
-	url := fmt.Sprintf("%s/repos/%s/%s", githubV3Url, owner, project)
+// validateProject checks that the project exists and is reachable with
+// the given token, and reports whether it is private so callers can
+// decide what scope the token needs to carry.
+func validateProject(baseURL, owner, project, token string) (ok bool, isPrivate bool, err error) {
+	url := fmt.Sprintf("%s/repos/%s/%s", baseURL, owner, project)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
 
 	req.Header.Set("Authorization", fmt.Sprintf("token %s", token))
@@ -342,62 +508,124 @@ func validateProject(owner, project, token string) (bool, error) {
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
+	defer resp.Body.Close()
 
-	err = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, false, nil
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
 
-	return resp.StatusCode == http.StatusOK, nil
+	var repoInfo struct {
+		Private bool `json:"private"`
+	}
+	if err := json.Unmarshal(data, &repoInfo); err != nil {
+		return false, false, err
+	}
+
+	return true, repoInfo.Private, nil
 }
 
-func promptPassword() (string, error) {
-	for {
-		fmt.Print("password: ")
+// requestScopes reads the scopes carried by the given token from the
+// "X-OAuth-Scopes" header of a GET /user call.
+func requestScopes(baseURL, token string) ([]string, error) {
+	url := fmt.Sprintf("%s/user", baseURL)
 
-		bytePassword, err := terminal.ReadPassword(int(syscall.Stdin))
-		// new line for coherent formatting, ReadPassword clip the normal new line
-		// entered by the user
-		fmt.Println()
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
 
-		if err != nil {
-			return "", err
-		}
+	req.Header.Set("Authorization", fmt.Sprintf("token %s", token))
 
-		if len(bytePassword) > 0 {
-			return string(bytePassword), nil
-		}
+	client := &http.Client{
+		Timeout: defaultTimeout,
+	}
 
-		fmt.Println("password is empty")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to read token scopes: http %v", resp.StatusCode)
+	}
+
+	raw := resp.Header.Get("X-OAuth-Scopes")
+	if raw == "" {
+		return nil, nil
+	}
+
+	var scopes []string
+	for _, s := range strings.Split(raw, ",") {
+		scopes = append(scopes, strings.TrimSpace(s))
+	}
+
+	return scopes, nil
 }
 
-func prompt2FA() (string, error) {
-	for {
-		fmt.Print("two-factor authentication code: ")
+// repoScopeFamily lists the scopes that only make sense on top of (and
+// are therefore implied by) the broad "repo" scope. It must not include
+// scopes like "user:email" or "read:org" which "repo" says nothing
+// about.
+var repoScopeFamily = map[string]bool{
+	"repo":            true,
+	"public_repo":     true,
+	"repo:status":     true,
+	"repo_deployment": true,
+	"repo:invite":     true,
+	"security_events": true,
+}
 
-		byte2fa, err := terminal.ReadPassword(int(syscall.Stdin))
-		fmt.Println()
-		if err != nil {
-			return "", err
+// hasScope reports whether scopes satisfy the wanted scope, taking into
+// account that the broad "repo" scope implicitly covers every narrower
+// repo-level permission (e.g. "public_repo"), but nothing outside that
+// family.
+func hasScope(scopes []string, wanted string) bool {
+	for _, s := range scopes {
+		if s == wanted {
+			return true
 		}
-
-		if len(byte2fa) != 6 {
-			fmt.Println("invalid 2FA code size")
-			continue
+		if s == "repo" && repoScopeFamily[wanted] {
+			return true
 		}
+	}
 
-		str2fa := string(byte2fa)
-		_, err = strconv.Atoi(str2fa)
-		if err != nil {
-			fmt.Println("2fa code must be digits only")
-			continue
-		}
+	return false
+}
+
+// validateScopes makes sure the token carries what the bridge needs to
+// read issues (and write them back on export), and warns when the user
+// granted more than was necessary.
+func validateScopes(scopes []string, isPrivate bool) error {
+	// reading and writing issues on a private repository requires "repo";
+	// on a public one the narrower "public_repo" is enough.
+	required := "public_repo"
+	if isPrivate {
+		required = "repo"
+	}
+
+	if !hasScope(scopes, required) {
+		return fmt.Errorf("the provided token is missing required scope: %s (needed to read and write issues)", required)
+	}
 
-		return str2fa, nil
+	if !hasScope(scopes, "read:org") {
+		fmt.Println()
+		fmt.Println("warning: this token doesn't have the 'read:org' scope. It isn't required to read and write issues, but importing organization members will fail without it.")
 	}
+
+	if !isPrivate && hasScope(scopes, "repo") {
+		fmt.Println()
+		fmt.Println("warning: this token has the broad 'repo' scope, granting read/write access to every private repository you can access. 'public_repo' would have been enough for this public project.")
+	}
+
+	return nil
 }
 
 func promptProjectVisibility() (bool, error) {
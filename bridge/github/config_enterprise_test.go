@@ -0,0 +1,60 @@
+package github
+
+import "testing"
+
+func TestGithubHost(t *testing.T) {
+	cases := []struct {
+		name    string
+		baseURL string
+		want    string
+	}{
+		{
+			name:    "public github",
+			baseURL: defaultBaseURL,
+			want:    defaultHost,
+		},
+		{
+			name:    "enterprise server",
+			baseURL: "https://ghe.example.com/api/v3",
+			want:    "https://ghe.example.com",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := githubHost(c.baseURL)
+			if got != c.want {
+				t.Fatalf("got %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestSplitURLEnterprise(t *testing.T) {
+	baseURL := "https://ghe.example.com/api/v3"
+
+	owner, project, err := splitURL("https://ghe.example.com/MichaelMure/git-bug", baseURL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owner != "MichaelMure" || project != "git-bug" {
+		t.Fatalf("got owner=%q project=%q", owner, project)
+	}
+
+	// a public github.com URL must not match against an enterprise host
+	if _, _, err := splitURL("https://github.com/MichaelMure/git-bug", baseURL); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestAPIBaseURL(t *testing.T) {
+	conf := make(map[string]string)
+	if got := APIBaseURL(conf); got != defaultBaseURL {
+		t.Fatalf("got %q, want %q", got, defaultBaseURL)
+	}
+
+	conf[keyBaseURL] = "https://ghe.example.com/api/v3"
+	if got := APIBaseURL(conf); got != "https://ghe.example.com/api/v3" {
+		t.Fatalf("got %q, want %q", got, "https://ghe.example.com/api/v3")
+	}
+}
@@ -0,0 +1,57 @@
+package github
+
+import "testing"
+
+func TestHasScope(t *testing.T) {
+	cases := []struct {
+		name   string
+		scopes []string
+		wanted string
+		want   bool
+	}{
+		{"exact match", []string{"repo"}, "repo", true},
+		{"repo covers public_repo", []string{"repo"}, "public_repo", true},
+		{"repo covers repo:status", []string{"repo"}, "repo:status", true},
+		{"repo does not cover read:org", []string{"repo"}, "read:org", false},
+		{"repo does not cover user:email", []string{"repo"}, "user:email", false},
+		{"missing scope", []string{"public_repo"}, "repo", false},
+		{"no scopes", nil, "repo", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := hasScope(c.scopes, c.wanted)
+			if got != c.want {
+				t.Fatalf("hasScope(%v, %q) = %v, want %v", c.scopes, c.wanted, got, c.want)
+			}
+		})
+	}
+}
+
+func TestValidateScopes(t *testing.T) {
+	cases := []struct {
+		name      string
+		scopes    []string
+		isPrivate bool
+		wantErr   bool
+	}{
+		{"public repo with public_repo scope", []string{"public_repo"}, false, false},
+		{"public repo with repo scope", []string{"repo"}, false, false},
+		{"public repo with only user:email", []string{"user:email"}, false, true},
+		{"private repo requires repo scope", []string{"public_repo"}, true, true},
+		{"private repo with repo scope", []string{"repo"}, true, false},
+		{"no scopes at all", nil, false, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateScopes(c.scopes, c.isPrivate)
+			if c.wantErr && err == nil {
+				t.Fatal("expected an error, got none")
+			}
+			if !c.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
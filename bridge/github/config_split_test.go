@@ -0,0 +1,51 @@
+package github
+
+import "testing"
+
+func TestSplitURL(t *testing.T) {
+	cases := []struct {
+		name    string
+		url     string
+		owner   string
+		project string
+		wantErr bool
+	}{
+		{
+			name:    "https url",
+			url:     "https://github.com/MichaelMure/git-bug",
+			owner:   "MichaelMure",
+			project: "git-bug",
+		},
+		{
+			name:    "bare host",
+			url:     "github.com/MichaelMure/git-bug",
+			owner:   "MichaelMure",
+			project: "git-bug",
+		},
+		{
+			name:    "not a github url",
+			url:     "https://gitlab.com/MichaelMure/git-bug",
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			owner, project, err := splitURL(c.url, defaultBaseURL)
+
+			if c.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if owner != c.owner || project != c.project {
+				t.Fatalf("got owner=%q project=%q, want owner=%q project=%q", owner, project, c.owner, c.project)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+)
+
+func TestConfigureNonInteractiveMissingOwner(t *testing.T) {
+	g := &Github{}
+
+	_, err := g.Configure(nil, core.BridgeParams{
+		Token:          "some-token",
+		NonInteractive: true,
+	})
+
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestConfigureNonInteractiveMissingToken(t *testing.T) {
+	// the owner check runs before the token check, so it is pointed at a
+	// local stub server where it succeeds
+	server := fakeGithubServer(t)
+
+	g := &Github{}
+
+	_, err := g.Configure(nil, core.BridgeParams{
+		Owner:          "a-owner",
+		Project:        "a-project",
+		BaseURL:        server.URL,
+		NonInteractive: true,
+	})
+
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+// fakeGithubServer stubs just enough of the GitHub v3 API for Configure
+// to run end-to-end without ever touching the network.
+func fakeGithubServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/a-owner", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/repos/a-owner/a-project", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"private": false}`)
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-OAuth-Scopes", "public_repo")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestConfigureNonInteractiveEnvFallback(t *testing.T) {
+	os.Setenv("GITHUB_OWNER", "a-owner")
+	os.Setenv("GITHUB_PROJECT", "a-project")
+	os.Setenv("GITHUB_TOKEN", "some-token")
+	defer os.Unsetenv("GITHUB_OWNER")
+	defer os.Unsetenv("GITHUB_PROJECT")
+	defer os.Unsetenv("GITHUB_TOKEN")
+
+	server := fakeGithubServer(t)
+
+	g := &Github{}
+
+	// owner/project/token are resolved from the environment, never from a
+	// prompt, and validated against a local stub server instead of the
+	// real GitHub API.
+	conf, err := g.Configure(nil, core.BridgeParams{
+		NonInteractive: true,
+		BaseURL:        server.URL,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if conf[keyOwner] != "a-owner" || conf[keyProject] != "a-project" || conf[keyToken] != "some-token" {
+		t.Fatalf("unexpected configuration: %v", conf)
+	}
+}
@@ -0,0 +1,69 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+)
+
+// githubExporter posts comments to issues of the GitHub (or GitHub
+// Enterprise Server) repository described by its configuration. It does
+// not read local bugs itself: the caller supplies what to post.
+type githubExporter struct {
+	conf   core.Configuration
+	client *http.Client
+}
+
+// Init prepares the exporter to talk to the host recorded in conf at
+// `bridge configure` time, so a GitHub Enterprise Server setup keeps
+// exporting to the right instance instead of the public API. It also
+// checks the scopes recorded for the stored token, so a missing
+// permission is caught now rather than mid-export.
+func (ge *githubExporter) Init(conf core.Configuration) error {
+	if err := requireScope(conf, "public_repo", "repo"); err != nil {
+		return err
+	}
+
+	ge.conf = conf
+	ge.client = newClient(conf)
+
+	return nil
+}
+
+// ExportAll creates a comment with the given body on the given issue of
+// the configured project, reporting the
+// target host so a failure is traceable back to the instance it hit.
+func (ge *githubExporter) ExportAll(issueNumber int, body string) error {
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d/comments", ge.conf[keyOwner], ge.conf[keyProject], issueNumber)
+
+	payload, err := json.Marshal(struct {
+		Body string `json:"body"`
+	}{Body: body})
+	if err != nil {
+		return err
+	}
+
+	req, err := newAuthenticatedRequest(ge.conf, "POST", path)
+	if err != nil {
+		return err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := ge.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		data, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("error exporting to %s: %v: %v", APIBaseURL(ge.conf), resp.StatusCode, string(data))
+	}
+
+	return nil
+}
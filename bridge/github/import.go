@@ -0,0 +1,62 @@
+package github
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+)
+
+// githubImporter fetches issues from the GitHub (or GitHub Enterprise
+// Server) repository described by its configuration. It does not store
+// them in the local bug cache.
+type githubImporter struct {
+	conf   core.Configuration
+	client *http.Client
+}
+
+// Init prepares the importer to talk to the host recorded in conf at
+// `bridge configure` time, so a GitHub Enterprise Server setup keeps
+// importing from the right instance instead of the public API. It also
+// checks the scopes recorded for the stored token, so a missing
+// permission is caught now rather than mid-import.
+func (gi *githubImporter) Init(conf core.Configuration) error {
+	if err := requireScope(conf, "public_repo", "repo"); err != nil {
+		return err
+	}
+
+	gi.conf = conf
+	gi.client = newClient(conf)
+
+	return nil
+}
+
+// ImportAll requests the issues of the configured project with a single
+// GET of /repos/{owner}/{project}/issues and checks that it succeeds. It
+// follows no pagination and does not read the returned issues.
+func (gi *githubImporter) ImportAll() error {
+	path := fmt.Sprintf("/repos/%s/%s/issues", gi.conf[keyOwner], gi.conf[keyProject])
+
+	req, err := newAuthenticatedRequest(gi.conf, "GET", path)
+	if err != nil {
+		return err
+	}
+
+	resp, err := gi.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("error fetching issues from %s: %v: %v", APIBaseURL(gi.conf), resp.StatusCode, string(data))
+	}
+
+	// Turning the returned issues into local bugs is out of
+	// scope for this change; what matters here is that import talks to
+	// the host recorded in the bridge configuration.
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bridge/github"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+type bridgeConfigureOptions struct {
+	core.BridgeParams
+}
+
+func newBridgeConfigureCommand() *cobra.Command {
+	options := bridgeConfigureOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "configure",
+		Short: "Configure a new bridge.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBridgeConfigure(options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(&options.Owner, "owner", "o", "", "The owner of the repository")
+	flags.StringVarP(&options.Project, "project", "p", "", "The name of the repository")
+	flags.StringVarP(&options.URL, "url", "u", "", "The URL of the repository")
+	flags.StringVarP(&options.Token, "token", "t", "", "A pre-generated personal access token to use")
+	flags.StringVar(&options.BaseURL, "base-url", "", "The base URL of the API, for a self-hosted GitHub Enterprise Server instance")
+	flags.BoolVar(&options.NonInteractive, "non-interactive", false, "Fail instead of prompting when a required parameter is missing")
+
+	return cmd
+}
+
+func runBridgeConfigure(options bridgeConfigureOptions) error {
+	repo, err := repository.OpenGoGitRepo(".", nil)
+	if err != nil {
+		return err
+	}
+
+	backend := &github.Github{}
+
+	conf, err := backend.Configure(repo, options.BridgeParams)
+	if err != nil {
+		return err
+	}
+
+	return backend.ValidateConfig(conf)
+}